@@ -0,0 +1,13 @@
+//go:build !kafka && !kafka_franz
+
+package kafka
+
+import "fmt"
+
+// newAdminClientForConfig is a build-time placeholder for the default, no-tag build: neither the
+// confluent-kafka-go backend (build tag "kafka") nor the franz-go backend (build tag "kafka_franz")
+// is compiled in, so there is no Kafka client library available to construct an AdminClient from.
+// Build with one of those tags to use TopicManager.
+func newAdminClientForConfig(config *KafkaConfig) (AdminClient, error) {
+	return nil, fmt.Errorf("kafka: no backend compiled in, build with -tags kafka or -tags kafka_franz")
+}
@@ -0,0 +1,210 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// sourceEventsTopic is a topic for sources to publish their resource create/update/delete events, the first
+	// asterisk is a wildcard for source, the second asterisk is a wildcard for cluster.
+	sourceEventsTopic = "sourceevents.*.*"
+	// agentEventsTopic is a topic for agents to publish their resource status update events, the first
+	// asterisk is a wildcard for source, the second asterisk is a wildcard for cluster.
+	agentEventsTopic = "agentevents.*.*"
+	// sourceBroadcastTopic is for a source to publish its events to all agents, the asterisk is a wildcard for source.
+	sourceBroadcastTopic = "sourcebroadcast.*"
+	// agentBroadcastTopic is for a agent to publish its events to all sources, the asterisk is a wildcard for cluster.
+	agentBroadcastTopic = "agentbroadcast.*"
+)
+
+// requiredTopics lists the topics the generic Kafka source/agent driver needs to function.
+var requiredTopics = []string{sourceEventsTopic, agentEventsTopic, sourceBroadcastTopic, agentBroadcastTopic}
+
+// Backend identifies which underlying Kafka client library the generic Kafka protocol binding
+// is built against. At most one backend is compiled in, selected by build tag: "kafka" compiles
+// in confluent-kafka-go/librdkafka (CGO), "kafka_franz" compiles in the pure-Go twmb/franz-go
+// client for CGO-free/scratch images and cross-compilation. A build with neither tag has no
+// backend.
+type Backend string
+
+const (
+	BackendConfluent Backend = "confluent"
+	BackendFranz     Backend = "franz"
+)
+
+// Producer is the subset of Kafka producer behavior the generic cloudevents Kafka protocol
+// binding needs, independent of the underlying client library.
+type Producer interface {
+	Produce(topic string, key, value []byte, headers map[string]string) error
+	Close()
+}
+
+// Consumer is the subset of Kafka consumer behavior the generic cloudevents Kafka protocol
+// binding needs, independent of the underlying client library.
+type Consumer interface {
+	Subscribe(topics []string) error
+	Close()
+}
+
+// TopicSpec describes a required topic and the settings it should be created with if missing.
+type TopicSpec struct {
+	Name              string
+	NumPartitions     int
+	ReplicationFactor int
+	ConfigEntries     map[string]string
+}
+
+// AutoCreateTopics controls whether the required event topics (sourceevents.*.*, agentevents.*.*,
+// sourcebroadcast.*, agentbroadcast.*) are created and kept in sync on startup.
+type AutoCreateTopics struct {
+	// Enable turns on automatic topic creation/validation using the Kafka AdminClient.
+	Enable bool `json:"enable" yaml:"enable"`
+	// NumPartitions is the partition count used when creating a missing topic. Defaults to 1.
+	NumPartitions int `json:"numPartitions,omitempty" yaml:"numPartitions,omitempty"`
+	// ReplicationFactor is the replication factor used when creating a missing topic. Defaults to 1.
+	ReplicationFactor int `json:"replicationFactor,omitempty" yaml:"replicationFactor,omitempty"`
+	// ConfigEntries are additional per-topic configuration entries (e.g. retention.ms) applied
+	// when creating a missing topic.
+	ConfigEntries map[string]string `json:"configEntries,omitempty" yaml:"configEntries,omitempty"`
+}
+
+// AdminClient is the subset of Kafka administrative behavior TopicManager needs to provision and
+// validate the required topics, independent of the underlying client library. EnsureTopics checks
+// partition count on topics that already exist; replication factor and ConfigEntries are only
+// applied when creating a topic, not validated against an existing one.
+type AdminClient interface {
+	// EnsureTopics creates any of the given topics that don't already exist and validates the
+	// partition count of the ones that do, logging a warning on mismatch.
+	EnsureTopics(ctx context.Context, topics []TopicSpec) error
+	Close()
+}
+
+// KafkaConfig is the YAML-serializable configuration shared by both backends: the fields it
+// carries (TLS, SASL, OAuth, topic auto-creation, ...) are translated into backend-specific
+// client options by each backend's own BuildKafkaOptionsFromFlags (build tag "kafka" for
+// confluent-kafka-go, "kafka_franz" for franz-go).
+type KafkaConfig struct {
+	// BootstrapServer is the host of the Kafka broker (hostname:port).
+	BootstrapServer string `json:"bootstrapServer" yaml:"bootstrapServer"`
+
+	// CAFile is the file path to a cert file for the Kafka broker certificate authority.
+	CAFile string `json:"caFile,omitempty" yaml:"caFile,omitempty"`
+	// ClientCertFile is the file path to a client cert file for TLS.
+	ClientCertFile string `json:"clientCertFile,omitempty" yaml:"clientCertFile,omitempty"`
+	// ClientKeyFile is the file path to a client key file for TLS.
+	ClientKeyFile string `json:"clientKeyFile,omitempty" yaml:"clientKeyFile,omitempty"`
+
+	// GroupID is a string that uniquely identifies the group of consumer processes to which this consumer belongs.
+	// Each different application will have a unique consumer GroupID. The default value is agentID for agent, sourceID for source
+	GroupID string `json:"groupID,omitempty" yaml:"groupID,omitempty"`
+
+	// SASL holds the SASL authentication settings used to connect to brokers that don't allow
+	// anonymous or mTLS-only access (e.g. Confluent Cloud, MSK, Aiven).
+	SASL *SASLConfig `json:"sasl,omitempty" yaml:"sasl,omitempty"`
+
+	// OAuth holds the OIDC client credentials used when SASL.Mechanism is OAUTHBEARER.
+	OAuth *OAuthConfig `json:"oauth,omitempty" yaml:"oauth,omitempty"`
+
+	// AutoCreateTopics controls whether the required event topics are created and kept in
+	// sync on startup using the Kafka AdminClient, see TopicManager.
+	AutoCreateTopics AutoCreateTopics `json:"autoCreateTopics,omitempty" yaml:"autoCreateTopics,omitempty"`
+
+	// DeliveryRetries is the number of times the producer retries delivering a message before
+	// giving up and reporting a delivery failure. Defaults to 0 (no retries).
+	DeliveryRetries int `json:"deliveryRetries,omitempty" yaml:"deliveryRetries,omitempty"`
+	// DeadLetterTopic, if set, receives a copy of any message that fails delivery after
+	// DeliveryRetries attempts, with the original headers plus an added x-failure-reason header.
+	// It is only used when ProducerOptions.DeadLetterHandler isn't set.
+	DeadLetterTopic string `json:"deadLetterTopic,omitempty" yaml:"deadLetterTopic,omitempty"`
+}
+
+// SASLMechanism identifies a SASL mechanism accepted in SASLConfig.Mechanism.
+type SASLMechanism string
+
+const (
+	SASLMechanismPlain       SASLMechanism = "PLAIN"
+	SASLMechanismScramSHA256 SASLMechanism = "SCRAM-SHA-256"
+	SASLMechanismScramSHA512 SASLMechanism = "SCRAM-SHA-512"
+	// SASLMechanismAWSMSKIAM authenticates to an MSK cluster via SigV4-signed tokens. librdkafka
+	// has no AWS_MSK_IAM sasl.mechanisms value: on the wire this is carried as OAUTHBEARER, with
+	// the signed token supplied by StartAWSMSKIAMTokenRefresher (build tag "kafka").
+	SASLMechanismAWSMSKIAM SASLMechanism = "AWS_MSK_IAM"
+	// SASLMechanismOAuthBearer authenticates using a JWT obtained via OAuthConfig and kept fresh
+	// by StartOAuthTokenRefresher (build tag "kafka"), rather than a static username/password.
+	SASLMechanismOAuthBearer SASLMechanism = "OAUTHBEARER"
+)
+
+// SASLConfig carries the SASL credentials for brokers that require authenticated access.
+type SASLConfig struct {
+	// Enable turns on SASL authentication for the connection.
+	Enable bool `json:"enable" yaml:"enable"`
+	// Mechanism is the SASL mechanism to use, one of PLAIN, SCRAM-SHA-256, SCRAM-SHA-512,
+	// AWS_MSK_IAM or OAUTHBEARER.
+	Mechanism SASLMechanism `json:"mechanism" yaml:"mechanism"`
+	// Username is the SASL username. Only used by PLAIN and SCRAM-SHA-* mechanisms.
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+	// Password is the SASL password. Only used by PLAIN and SCRAM-SHA-* mechanisms.
+	Password string `json:"password,omitempty" yaml:"password,omitempty"`
+	// Region is the AWS region used to sign MSK IAM auth tokens. Required when Mechanism is
+	// AWS_MSK_IAM, see StartAWSMSKIAMTokenRefresher.
+	Region string `json:"region,omitempty" yaml:"region,omitempty"`
+}
+
+// OAuthConfig configures SASL/OAUTHBEARER authentication via the OAuth2 client credentials flow,
+// letting the SDK talk to Kafka clusters fronted by Keycloak/Okta/Azure AD without shipping
+// long-lived SASL passwords in config files.
+type OAuthConfig struct {
+	// TokenURL is the OIDC provider's token endpoint.
+	TokenURL string `json:"tokenURL" yaml:"tokenURL"`
+	// ClientID is the OAuth2 client ID used for the client credentials grant.
+	ClientID string `json:"clientID" yaml:"clientID"`
+	// ClientSecret is the OAuth2 client secret used for the client credentials grant.
+	ClientSecret string `json:"clientSecret" yaml:"clientSecret"`
+	// Scopes are the OAuth2 scopes requested for the access token.
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+}
+
+// loadKafkaConfig reads and unmarshals a KafkaConfig from a config filepath. It has no backend
+// dependency, so it's shared by both backends' BuildKafkaOptionsFromFlags, by NewTopicManagerFromFlags,
+// and by BuildLoaderOptionsFromFlags (used by work.ConfigLoader), regardless of build tag.
+func loadKafkaConfig(configPath string) (*KafkaConfig, error) {
+	configData, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: failed to unmarshal the data to kafka.ConfigMap directly.
+	// Further investigation is required to understand the reasons behind it.
+	config := &KafkaConfig{}
+	if err := yaml.Unmarshal(configData, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// LoaderOptions is the backend-neutral result of loading a kafka config section through
+// work.ConfigLoader. Unlike KafkaOptions (a librdkafka ConfigMap, build tag "kafka") or the
+// franz-go []kgo.Opt (build tag "kafka_franz"), it carries no backend-specific client types, so
+// work.ConfigLoader can depend on it regardless of which backend build tag is active.
+type LoaderOptions struct {
+	Config *KafkaConfig
+}
+
+// BuildLoaderOptionsFromFlags loads a KafkaConfig from configPath for work.ConfigLoader, without
+// translating it into either backend's client options, since the backend isn't known at this
+// layer. It checks only that bootstrapServer is set; the TLS and SASL checks of
+// BuildKafkaOptionsFromFlags are not applied.
+func BuildLoaderOptionsFromFlags(configPath string) (*LoaderOptions, error) {
+	config, err := loadKafkaConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if config.BootstrapServer == "" {
+		return nil, fmt.Errorf("bootstrapServer is required")
+	}
+	return &LoaderOptions{Config: config}, nil
+}
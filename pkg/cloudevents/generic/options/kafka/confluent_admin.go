@@ -0,0 +1,76 @@
+//go:build kafka && !kafka_franz
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kafkav2 "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"k8s.io/klog/v2"
+)
+
+// defaultAdminRequestTimeout bounds AdminClient metadata requests.
+const defaultAdminRequestTimeout = 30 * time.Second
+
+// confluentAdminClient implements AdminClient on top of confluent-kafka-go/librdkafka.
+type confluentAdminClient struct {
+	admin *kafkav2.AdminClient
+}
+
+// NewConfluentAdminClient wraps a confluent-kafka-go AdminClient connected to bootstrapServers.
+func NewConfluentAdminClient(bootstrapServers string) (AdminClient, error) {
+	admin, err := kafkav2.NewAdminClient(&kafkav2.ConfigMap{"bootstrap.servers": bootstrapServers})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka admin client: %w", err)
+	}
+	return &confluentAdminClient{admin: admin}, nil
+}
+
+func newAdminClientForConfig(config *KafkaConfig) (AdminClient, error) {
+	return NewConfluentAdminClient(config.BootstrapServer)
+}
+
+func (c *confluentAdminClient) EnsureTopics(ctx context.Context, topics []TopicSpec) error {
+	metadata, err := c.admin.GetMetadata(nil, true, int(defaultAdminRequestTimeout.Milliseconds()))
+	if err != nil {
+		return fmt.Errorf("failed to fetch kafka metadata: %w", err)
+	}
+
+	var missing []kafkav2.TopicSpecification
+	for _, topic := range topics {
+		existing, ok := metadata.Topics[topic.Name]
+		if !ok || existing.Error.Code() == kafkav2.ErrUnknownTopicOrPart {
+			missing = append(missing, kafkav2.TopicSpecification{
+				Topic:             topic.Name,
+				NumPartitions:     topic.NumPartitions,
+				ReplicationFactor: topic.ReplicationFactor,
+				Config:            topic.ConfigEntries,
+			})
+			continue
+		}
+		if len(existing.Partitions) != topic.NumPartitions {
+			klog.Warningf("kafka topic %q has %d partitions, expected %d", topic.Name, len(existing.Partitions), topic.NumPartitions)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	results, err := c.admin.CreateTopics(ctx, missing)
+	if err != nil {
+		return fmt.Errorf("failed to create kafka topics: %w", err)
+	}
+	for _, result := range results {
+		if result.Error.Code() != kafkav2.ErrNoError && result.Error.Code() != kafkav2.ErrTopicAlreadyExists {
+			return fmt.Errorf("failed to create kafka topic %q: %w", result.Topic, result.Error)
+		}
+	}
+	return nil
+}
+
+func (c *confluentAdminClient) Close() {
+	c.admin.Close()
+}
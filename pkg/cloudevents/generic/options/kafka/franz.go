@@ -0,0 +1,92 @@
+//go:build kafka_franz
+
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+)
+
+// BuildKafkaOptionsFromFlags builds franz-go client options from the same config file as the
+// confluent-kafka-go backed BuildKafkaOptionsFromFlags (build tag "kafka"), so CGO-free binaries
+// can be produced for scratch/alpine images and cross-compilation without changing how callers
+// load their config. The translation differs from the confluent one: SASL is always dialed over
+// TLS, only PLAIN and SCRAM-SHA-* mechanisms are accepted, and DeliveryRetries is not applied.
+func BuildKafkaOptionsFromFlags(configPath string) ([]kgo.Opt, error) {
+	config, err := loadKafkaConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.BootstrapServer == "" {
+		return nil, fmt.Errorf("bootstrapServer is required")
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(config.BootstrapServer),
+	}
+
+	if config.GroupID != "" {
+		opts = append(opts, kgo.ConsumerGroup(config.GroupID))
+	}
+
+	// TLS is required both for mTLS (a client cert) and for sasl_ssl (SASL over TLS, the normal
+	// way to run SASL against a real broker) — not only when a client cert is configured.
+	if config.CAFile != "" || (config.SASL != nil && config.SASL.Enable) {
+		tlsConfig := &tls.Config{}
+
+		if config.CAFile != "" {
+			caCert, err := os.ReadFile(config.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read caFile: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("failed to parse caFile %q as PEM", config.CAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		if config.ClientCertFile != "" {
+			if config.ClientKeyFile == "" || config.CAFile == "" {
+				return nil, fmt.Errorf("clientCertFile requires clientKeyFile and caFile to also be set")
+			}
+			cert, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+
+		opts = append(opts, kgo.DialTLSConfig(tlsConfig))
+	}
+
+	if config.SASL != nil && config.SASL.Enable {
+		switch config.SASL.Mechanism {
+		case SASLMechanismPlain, SASLMechanismScramSHA256, SASLMechanismScramSHA512:
+			if config.SASL.Username == "" || config.SASL.Password == "" {
+				return nil, fmt.Errorf("sasl.username and sasl.password are required for mechanism %s", config.SASL.Mechanism)
+			}
+		}
+		switch config.SASL.Mechanism {
+		case SASLMechanismPlain:
+			opts = append(opts, kgo.SASL(plain.Auth{User: config.SASL.Username, Pass: config.SASL.Password}.AsMechanism()))
+		case SASLMechanismScramSHA256:
+			opts = append(opts, kgo.SASL(scram.Auth{User: config.SASL.Username, Pass: config.SASL.Password}.AsSha256Mechanism()))
+		case SASLMechanismScramSHA512:
+			opts = append(opts, kgo.SASL(scram.Auth{User: config.SASL.Username, Pass: config.SASL.Password}.AsSha512Mechanism()))
+		default:
+			// AWS_MSK_IAM and OAUTHBEARER are handled only by the confluent-kafka-go backend
+			// (build tag "kafka"); this backend rejects them.
+			return nil, fmt.Errorf("unsupported sasl mechanism for the franz-go backend: %q", config.SASL.Mechanism)
+		}
+	}
+
+	return opts, nil
+}
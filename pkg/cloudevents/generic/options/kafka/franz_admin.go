@@ -0,0 +1,60 @@
+//go:build kafka_franz
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"k8s.io/klog/v2"
+)
+
+// franzAdminClient implements AdminClient on top of twmb/franz-go, for CGO-free binaries.
+type franzAdminClient struct {
+	client *kadm.Client
+}
+
+// NewFranzAdminClient wraps a franz-go admin client connected to bootstrapServers.
+func NewFranzAdminClient(bootstrapServers string) (AdminClient, error) {
+	kafkaClient, err := kgo.NewClient(kgo.SeedBrokers(bootstrapServers))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	return &franzAdminClient{client: kadm.NewClient(kafkaClient)}, nil
+}
+
+func newAdminClientForConfig(config *KafkaConfig) (AdminClient, error) {
+	return NewFranzAdminClient(config.BootstrapServer)
+}
+
+func (c *franzAdminClient) EnsureTopics(ctx context.Context, topics []TopicSpec) error {
+	existing, err := c.client.ListTopics(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list kafka topics: %w", err)
+	}
+
+	for _, topic := range topics {
+		details, ok := existing[topic.Name]
+		if !ok {
+			configs := make(map[string]*string, len(topic.ConfigEntries))
+			for k, v := range topic.ConfigEntries {
+				v := v
+				configs[k] = &v
+			}
+			if _, err := c.client.CreateTopic(ctx, int32(topic.NumPartitions), int16(topic.ReplicationFactor), configs, topic.Name); err != nil {
+				return fmt.Errorf("failed to create kafka topic %q: %w", topic.Name, err)
+			}
+			continue
+		}
+		if len(details.Partitions) != topic.NumPartitions {
+			klog.Warningf("kafka topic %q has %d partitions, expected %d", topic.Name, len(details.Partitions), topic.NumPartitions)
+		}
+	}
+	return nil
+}
+
+func (c *franzAdminClient) Close() {
+	c.client.Close()
+}
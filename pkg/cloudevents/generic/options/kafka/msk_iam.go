@@ -0,0 +1,54 @@
+//go:build kafka && !kafka_franz
+
+package kafka
+
+import (
+	"context"
+	"time"
+
+	signer "github.com/aws/aws-msk-iam-sasl-signer-go/signer"
+	kafkav2 "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"k8s.io/klog/v2"
+)
+
+// StartAWSMSKIAMTokenRefresher signs a SigV4 auth token for MSK IAM authentication (mechanism
+// AWS_MSK_IAM, carried over the wire as sasl.mechanisms=OAUTHBEARER) and hands it to handle via
+// SetOAuthBearerToken, refreshing shortly before it expires until ctx is cancelled. Credentials
+// are resolved from the ambient AWS credential chain. Errors are sent to errCh; the caller should
+// keep reading from it, since a refresh failure leaves the previous token in place.
+func StartAWSMSKIAMTokenRefresher(ctx context.Context, handle oauthBearerHandle, region string, errCh chan error) {
+	refresh := func() time.Duration {
+		tokenValue, expirationMs, err := signer.GenerateAuthToken(ctx, region)
+		if err != nil {
+			klog.Errorf("failed to refresh aws msk iam token: %v", err)
+			_ = handle.SetOAuthBearerTokenFailure(err.Error())
+			errCh <- err
+			return oauthRetryInterval
+		}
+
+		token := kafkav2.OAuthBearerToken{
+			TokenValue: tokenValue,
+			Expiration: time.UnixMilli(expirationMs),
+		}
+		if err := handle.SetOAuthBearerToken(token); err != nil {
+			klog.Errorf("failed to set aws msk iam token: %v", err)
+			errCh <- err
+			return oauthRetryInterval
+		}
+		return time.Until(token.Expiration) - oauthRefreshMargin
+	}
+
+	go func() {
+		wait := refresh()
+		for {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				wait = refresh()
+			}
+		}
+	}()
+}
@@ -0,0 +1,108 @@
+//go:build kafka && !kafka_franz
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	kafkav2 "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// oauthRefreshMargin is how long before expiry StartOAuthTokenRefresher fetches a new token,
+	// so in-flight authentication never sees one that's about to lapse.
+	oauthRefreshMargin = 30 * time.Second
+	// oauthRetryInterval is how soon StartOAuthTokenRefresher retries after a failed fetch.
+	oauthRetryInterval = 10 * time.Second
+)
+
+// oauthBearerHandle is implemented by kafkav2.Producer, kafkav2.Consumer and kafkav2.AdminClient.
+type oauthBearerHandle interface {
+	SetOAuthBearerToken(oauthBearerToken kafkav2.OAuthBearerToken) error
+	SetOAuthBearerTokenFailure(errstr string) error
+}
+
+// oauthTokenResponse is the client_credentials grant response defined by RFC 6749.
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// fetchOAuthToken performs the OAuth2 client credentials flow against config.TokenURL.
+func fetchOAuthToken(ctx context.Context, config *OAuthConfig) (kafkav2.OAuthBearerToken, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(config.Scopes) > 0 {
+		form.Set("scope", strings.Join(config.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return kafkav2.OAuthBearerToken{}, err
+	}
+	req.SetBasicAuth(config.ClientID, config.ClientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return kafkav2.OAuthBearerToken{}, fmt.Errorf("failed to fetch oauth token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return kafkav2.OAuthBearerToken{}, fmt.Errorf("oauth token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var token oauthTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return kafkav2.OAuthBearerToken{}, fmt.Errorf("failed to decode oauth token response: %w", err)
+	}
+
+	return kafkav2.OAuthBearerToken{
+		TokenValue: token.AccessToken,
+		Expiration: time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// StartOAuthTokenRefresher fetches an OAuth2 access token via the client credentials flow and
+// hands it to handle with SetOAuthBearerToken, refreshing it shortly before it expires until ctx
+// is cancelled. Fetch or handoff failures are reported to handle via SetOAuthBearerTokenFailure
+// and sent to errCh; the caller should keep reading from errCh for alerting purposes.
+func StartOAuthTokenRefresher(ctx context.Context, handle oauthBearerHandle, config *OAuthConfig, errCh chan error) {
+	refresh := func() time.Duration {
+		token, err := fetchOAuthToken(ctx, config)
+		if err != nil {
+			klog.Errorf("failed to refresh oauth bearer token: %v", err)
+			_ = handle.SetOAuthBearerTokenFailure(err.Error())
+			errCh <- err
+			return oauthRetryInterval
+		}
+		if err := handle.SetOAuthBearerToken(token); err != nil {
+			klog.Errorf("failed to set oauth bearer token: %v", err)
+			_ = handle.SetOAuthBearerTokenFailure(err.Error())
+			errCh <- err
+			return oauthRetryInterval
+		}
+		return time.Until(token.Expiration) - oauthRefreshMargin
+	}
+
+	go func() {
+		wait := refresh()
+		for {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				wait = refresh()
+			}
+		}
+	}()
+}
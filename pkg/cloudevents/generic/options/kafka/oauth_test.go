@@ -0,0 +1,113 @@
+//go:build kafka && !kafka_franz
+
+package kafka
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	kafkav2 "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func TestFetchOAuthToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "client_credentials" {
+			t.Errorf("unexpected grant_type %q", got)
+		}
+		if got := r.PostForm.Get("scope"); got != "kafka" {
+			t.Errorf("unexpected scope %q", got)
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "agent" || pass != "secret" {
+			t.Errorf("unexpected basic auth %q:%q (ok=%v)", user, pass, ok)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"test-token","expires_in":60}`))
+	}))
+	defer server.Close()
+
+	config := &OAuthConfig{TokenURL: server.URL, ClientID: "agent", ClientSecret: "secret", Scopes: []string{"kafka"}}
+	token, err := fetchOAuthToken(context.Background(), config)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if token.TokenValue != "test-token" {
+		t.Errorf("unexpected token value %q", token.TokenValue)
+	}
+	if time.Until(token.Expiration) <= 0 || time.Until(token.Expiration) > 60*time.Second {
+		t.Errorf("unexpected expiration %v", token.Expiration)
+	}
+}
+
+func TestFetchOAuthToken_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := fetchOAuthToken(context.Background(), &OAuthConfig{TokenURL: server.URL})
+	if err == nil {
+		t.Fatal("expected an error for a non-200 response")
+	}
+}
+
+// fakeOAuthBearerHandle records the tokens/failures handed to it by StartOAuthTokenRefresher.
+type fakeOAuthBearerHandle struct {
+	mu       sync.Mutex
+	tokens   int
+	failures int
+}
+
+func (h *fakeOAuthBearerHandle) SetOAuthBearerToken(token kafkav2.OAuthBearerToken) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tokens++
+	return nil
+}
+
+func (h *fakeOAuthBearerHandle) SetOAuthBearerTokenFailure(errstr string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.failures++
+	return nil
+}
+
+func (h *fakeOAuthBearerHandle) snapshot() (int, int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.tokens, h.failures
+}
+
+func TestStartOAuthTokenRefresher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"test-token","expires_in":60}`))
+	}))
+	defer server.Close()
+
+	handle := &fakeOAuthBearerHandle{}
+	errCh := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	StartOAuthTokenRefresher(ctx, handle, &OAuthConfig{TokenURL: server.URL}, errCh)
+
+	deadline := time.After(time.Second)
+	for {
+		if tokens, _ := handle.snapshot(); tokens >= 1 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for the refresher to set an oauth bearer token")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -1,30 +1,16 @@
-//go:build kafka
+//go:build kafka && !kafka_franz
 
 package kafka
 
 import (
 	"fmt"
-	"os"
+	"strconv"
 
-	"gopkg.in/yaml.v2"
 	"k8s.io/klog/v2"
 
 	kafkav2 "github.com/confluentinc/confluent-kafka-go/v2/kafka"
 )
 
-const (
-	// sourceEventsTopic is a topic for sources to publish their resource create/update/delete events, the first
-	// asterisk is a wildcard for source, the second asterisk is a wildcard for cluster.
-	sourceEventsTopic = "sourceevents.*.*"
-	// agentEventsTopic is a topic for agents to publish their resource status update events, the first
-	// asterisk is a wildcard for source, the second asterisk is a wildcard for cluster.
-	agentEventsTopic = "agentevents.*.*"
-	// sourceBroadcastTopic is for a source to publish its events to all agents, the asterisk is a wildcard for source.
-	sourceBroadcastTopic = "sourcebroadcast.*"
-	// agentBroadcastTopic is for a agent to publish its events to all sources, the asterisk is a wildcard for cluster.
-	agentBroadcastTopic = "agentbroadcast.*"
-)
-
 type KafkaOptions map[string]interface{}
 
 func (opts *KafkaOptions) ConfigMap() kafkav2.ConfigMap {
@@ -35,26 +21,40 @@ func (opts *KafkaOptions) ConfigMap() kafkav2.ConfigMap {
 	return kafkaConfigMap
 }
 
-type KafkaConfig struct {
-	// BootstrapServer is the host of the Kafka broker (hostname:port).
-	BootstrapServer string `json:"bootstrapServer" yaml:"bootstrapServer"`
-
-	// CAFile is the file path to a cert file for the MQTT broker certificate authority.
-	CAFile string `json:"caFile,omitempty" yaml:"caFile,omitempty"`
-	// ClientCertFile is the file path to a client cert file for TLS.
-	ClientCertFile string `json:"clientCertFile,omitempty" yaml:"clientCertFile,omitempty"`
-	// ClientKeyFile is the file path to a client key file for TLS.
-	ClientKeyFile string `json:"clientKeyFile,omitempty" yaml:"clientKeyFile,omitempty"`
+// DeadLetterHandler is invoked for a message that failed delivery after DeliveryRetries attempts,
+// instead of republishing it to KafkaConfig.DeadLetterTopic.
+type DeadLetterHandler func(topic string, key, value []byte, headers map[string]string, reason error)
+
+// ProduceErrorCallback lets callers observe every producer delivery report, success or failure,
+// for metrics/alerting rather than relying solely on the klog line handleProduceEvents emits.
+type ProduceErrorCallback func(ev *kafkav2.Message)
+
+// ProducerOptions configures how handleProduceEvents reacts to delivery reports. Either field may
+// be left unset; a nil ProducerOptions disables both dead-lettering and the error callback.
+type ProducerOptions struct {
+	// DeadLetterTopic receives a copy of any message that fails delivery, with the original
+	// headers plus an added x-failure-reason header. Ignored if DeadLetterHandler is set.
+	DeadLetterTopic string
+	// DeadLetterHandler, if set, is called instead of republishing to DeadLetterTopic.
+	DeadLetterHandler DeadLetterHandler
+	// ProduceErrorCallback, if set, is called with every delivery report.
+	ProduceErrorCallback ProduceErrorCallback
+}
 
-	// GroupID is a string that uniquely identifies the group of consumer processes to which this consumer belongs.
-	// Each different application will have a unique consumer GroupID. The default value is agentID for agent, sourceID for source
-	GroupID string `json:"groupID,omitempty" yaml:"groupID,omitempty"`
+// BuildProducerOptions builds the ProducerOptions handleProduceEvents uses to react to delivery
+// reports, wiring up config's DeadLetterTopic alongside the caller's own callbacks.
+func BuildProducerOptions(config *KafkaConfig, callback ProduceErrorCallback, deadLetterHandler DeadLetterHandler) *ProducerOptions {
+	return &ProducerOptions{
+		DeadLetterTopic:      config.DeadLetterTopic,
+		DeadLetterHandler:    deadLetterHandler,
+		ProduceErrorCallback: callback,
+	}
 }
 
 // Listen to all the events on the default events channel
 // It's important to read these events otherwise the events channel will eventually fill up
 // Detail: https://github.com/cloudevents/sdk-go/blob/main/protocol/kafka_confluent/v2/protocol.go#L90
-func handleProduceEvents(producerEvents chan kafkav2.Event, errChan chan error) {
+func handleProduceEvents(producer *kafkav2.Producer, producerEvents chan kafkav2.Event, errChan chan error, opts *ProducerOptions) {
 	if producerEvents == nil {
 		return
 	}
@@ -62,9 +62,13 @@ func handleProduceEvents(producerEvents chan kafkav2.Event, errChan chan error)
 		for e := range producerEvents {
 			switch ev := e.(type) {
 			case *kafkav2.Message:
+				if opts != nil && opts.ProduceErrorCallback != nil {
+					opts.ProduceErrorCallback(ev)
+				}
 				// The message delivery report, indicating success or failure when sending message
 				if ev.TopicPartition.Error != nil {
 					klog.Errorf("Delivery failed: %v", ev.TopicPartition.Error)
+					handleDeliveryFailure(producer, ev, opts)
 				}
 			case kafkav2.Error:
 				// Generic client instance-level errors, such as
@@ -75,17 +79,51 @@ func handleProduceEvents(producerEvents chan kafkav2.Event, errChan chan error)
 	}()
 }
 
-// BuildKafkaOptionsFromFlags builds configs from a config filepath.
-func BuildKafkaOptionsFromFlags(configPath string) (*KafkaOptions, error) {
-	configData, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, err
+// handleDeliveryFailure routes a message that persistently failed delivery to the configured
+// DeadLetterHandler, or republishes it to DeadLetterTopic with an added x-failure-reason header.
+// It is a no-op if neither is configured, preserving the previous log-only behavior.
+func handleDeliveryFailure(producer *kafkav2.Producer, ev *kafkav2.Message, opts *ProducerOptions) {
+	if opts == nil {
+		return
+	}
+
+	if opts.DeadLetterHandler != nil {
+		opts.DeadLetterHandler(*ev.TopicPartition.Topic, ev.Key, ev.Value, headersToMap(ev.Headers), ev.TopicPartition.Error)
+		return
+	}
+
+	if opts.DeadLetterTopic == "" || producer == nil {
+		return
+	}
+
+	headers := append(append([]kafkav2.Header{}, ev.Headers...), kafkav2.Header{
+		Key:   "x-failure-reason",
+		Value: []byte(ev.TopicPartition.Error.Error()),
+	})
+	if err := producer.Produce(&kafkav2.Message{
+		TopicPartition: kafkav2.TopicPartition{Topic: &opts.DeadLetterTopic, Partition: kafkav2.PartitionAny},
+		Key:            ev.Key,
+		Value:          ev.Value,
+		Headers:        headers,
+	}, nil); err != nil {
+		klog.Errorf("failed to republish message to dead-letter topic %q: %v", opts.DeadLetterTopic, err)
 	}
+}
+
+func headersToMap(headers []kafkav2.Header) map[string]string {
+	m := make(map[string]string, len(headers))
+	for _, h := range headers {
+		m[h.Key] = string(h.Value)
+	}
+	return m
+}
 
-	// TODO: failed to unmarshal the data to kafka.ConfigMap directly.
-	// Further investigation is required to understand the reasons behind it.
-	config := &KafkaConfig{}
-	if err := yaml.Unmarshal(configData, config); err != nil {
+// BuildKafkaOptionsFromFlags builds configs from a config filepath for the confluent-kafka-go/
+// librdkafka backend. Build with the "kafka_franz" tag instead to target the CGO-free franz-go
+// backend, which has its own BuildKafkaOptionsFromFlags translating the same KafkaConfig.
+func BuildKafkaOptionsFromFlags(configPath string) (*KafkaOptions, error) {
+	config, err := loadKafkaConfig(configPath)
+	if err != nil {
 		return nil, err
 	}
 
@@ -101,6 +139,25 @@ func BuildKafkaOptionsFromFlags(configPath string) (*KafkaOptions, error) {
 		return nil, fmt.Errorf("setting clientCertFile and clientKeyFile requires caFile")
 	}
 
+	if config.SASL != nil && config.SASL.Enable {
+		switch config.SASL.Mechanism {
+		case SASLMechanismPlain, SASLMechanismScramSHA256, SASLMechanismScramSHA512:
+			if config.SASL.Username == "" || config.SASL.Password == "" {
+				return nil, fmt.Errorf("sasl.username and sasl.password are required for mechanism %s", config.SASL.Mechanism)
+			}
+		case SASLMechanismAWSMSKIAM:
+			if config.SASL.Region == "" {
+				return nil, fmt.Errorf("sasl.region is required for mechanism %s", config.SASL.Mechanism)
+			}
+		case SASLMechanismOAuthBearer:
+			if config.OAuth == nil || config.OAuth.TokenURL == "" || config.OAuth.ClientID == "" || config.OAuth.ClientSecret == "" {
+				return nil, fmt.Errorf("oauth.tokenURL, oauth.clientID and oauth.clientSecret are required for mechanism %s", config.SASL.Mechanism)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported sasl mechanism %q", config.SASL.Mechanism)
+		}
+	}
+
 	kafkaOptions := KafkaOptions{
 		"bootstrap.servers":       config.BootstrapServer,
 		"socket.keepalive.enable": true,
@@ -113,7 +170,7 @@ func BuildKafkaOptionsFromFlags(configPath string) (*KafkaOptions, error) {
 
 		// producer
 		"acks":    "1",
-		"retries": "0",
+		"retries": strconv.Itoa(config.DeliveryRetries),
 
 		// consumer
 		"group.id": config.GroupID,
@@ -146,6 +203,28 @@ func BuildKafkaOptionsFromFlags(configPath string) (*KafkaOptions, error) {
 		// _ = kafkaConfigMap.SetKey("ssl.certificate.location", config.ClientCertFile)
 		// _ = kafkaConfigMap.SetKey("ssl.key.location", config.ClientKeyFile)
 	}
+
+	if config.SASL != nil && config.SASL.Enable {
+		if config.CAFile != "" {
+			kafkaOptions["security.protocol"] = "sasl_ssl"
+			kafkaOptions["ssl.ca.location"] = config.CAFile
+		} else {
+			kafkaOptions["security.protocol"] = "sasl_plaintext"
+		}
+		switch config.SASL.Mechanism {
+		case SASLMechanismAWSMSKIAM:
+			// librdkafka has no AWS_MSK_IAM sasl.mechanisms value; MSK IAM is carried as
+			// OAUTHBEARER with a SigV4-signed token supplied by StartAWSMSKIAMTokenRefresher.
+			kafkaOptions["sasl.mechanisms"] = string(SASLMechanismOAuthBearer)
+		case SASLMechanismOAuthBearer:
+			// Token itself is supplied at runtime via StartOAuthTokenRefresher.
+			kafkaOptions["sasl.mechanisms"] = string(config.SASL.Mechanism)
+		default:
+			kafkaOptions["sasl.mechanisms"] = string(config.SASL.Mechanism)
+			kafkaOptions["sasl.username"] = config.SASL.Username
+			kafkaOptions["sasl.password"] = config.SASL.Password
+		}
+	}
 	return &kafkaOptions, nil
 }
 
@@ -0,0 +1,53 @@
+//go:build kafka && !kafka_franz
+
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	kafkav2 "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func TestHandleDeliveryFailure_DeadLetterHandler(t *testing.T) {
+	topic := "sourceevents.*.*"
+	deliveryErr := errors.New("delivery failed")
+	ev := &kafkav2.Message{
+		TopicPartition: kafkav2.TopicPartition{Topic: &topic, Error: deliveryErr},
+		Key:            []byte("key"),
+		Value:          []byte("value"),
+		Headers:        []kafkav2.Header{{Key: "x-source", Value: []byte("hub1")}},
+	}
+
+	var gotTopic string
+	var gotKey, gotValue []byte
+	var gotHeaders map[string]string
+	var gotErr error
+	opts := &ProducerOptions{
+		DeadLetterHandler: func(topic string, key, value []byte, headers map[string]string, reason error) {
+			gotTopic, gotKey, gotValue, gotHeaders, gotErr = topic, key, value, headers, reason
+		},
+	}
+
+	// producer is nil: handleDeliveryFailure must route through DeadLetterHandler without ever
+	// dereferencing it.
+	handleDeliveryFailure(nil, ev, opts)
+
+	if gotTopic != topic {
+		t.Errorf("expected topic %q, got %q", topic, gotTopic)
+	}
+	if string(gotKey) != "key" || string(gotValue) != "value" {
+		t.Errorf("expected key/value to carry through, got %q/%q", gotKey, gotValue)
+	}
+	if gotHeaders["x-source"] != "hub1" {
+		t.Errorf("expected headers to carry through, got %v", gotHeaders)
+	}
+	if !errors.Is(gotErr, deliveryErr) {
+		t.Errorf("expected the delivery error to carry through, got %v", gotErr)
+	}
+}
+
+func TestHandleDeliveryFailure_NilOpts(t *testing.T) {
+	// Must not panic when opts is nil.
+	handleDeliveryFailure(nil, &kafkav2.Message{}, nil)
+}
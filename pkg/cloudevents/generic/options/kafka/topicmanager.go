@@ -0,0 +1,120 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// defaultTopicRefreshInterval is how often the TopicManager re-checks cluster metadata
+	// for the required topics, akin to tiflow's kafkaTopicManager.
+	defaultTopicRefreshInterval = 10 * time.Minute
+
+	defaultNumPartitions     = 1
+	defaultReplicationFactor = 1
+)
+
+// TopicManager ensures the required event topics exist, creating missing ones with the configured
+// partition count, replication factor and config entries. For a topic that already exists only
+// the partition count is checked, and a mismatch is logged as a warning, not returned as an
+// error; its replication factor and config entries are not checked. StartRefresher periodically
+// repeats this to recreate topics that were deleted, surfacing errors on Errors().
+// It delegates the actual topic inspection/creation to an AdminClient, so it works unmodified
+// against either the confluent-kafka-go or franz-go backend.
+type TopicManager struct {
+	admin  AdminClient
+	config AutoCreateTopics
+	errCh  chan error
+}
+
+// NewTopicManager creates a TopicManager backed by the given AdminClient.
+func NewTopicManager(admin AdminClient, config AutoCreateTopics) *TopicManager {
+	return &TopicManager{
+		admin:  admin,
+		config: config,
+		errCh:  make(chan error, 1),
+	}
+}
+
+// NewTopicManagerFromFlags builds a TopicManager from the same config file used by
+// BuildKafkaOptionsFromFlags, constructing an AdminClient for whichever backend is compiled in.
+func NewTopicManagerFromFlags(configPath string) (*TopicManager, error) {
+	config, err := loadKafkaConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := newAdminClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewTopicManager(admin, config.AutoCreateTopics), nil
+}
+
+// Errors returns the channel on which StartRefresher surfaces errors from its periodic EnsureTopics
+// runs; a direct EnsureTopics call returns its error instead. Its
+// buffer holds exactly one error: StartRefresher drops an error on the floor (logging it) rather
+// than blocking on send, so a caller that never drains Errors() only loses visibility into
+// refresh failures, it doesn't leak the refresher goroutine.
+func (m *TopicManager) Errors() <-chan error {
+	return m.errCh
+}
+
+// EnsureTopics creates any of the required topics that don't exist yet and validates the
+// partition count of the ones that do. It is a no-op if auto-creation is disabled.
+func (m *TopicManager) EnsureTopics(ctx context.Context) error {
+	if !m.config.Enable {
+		return nil
+	}
+	return m.admin.EnsureTopics(ctx, m.specs())
+}
+
+// StartRefresher periodically re-validates the required topics until ctx is cancelled. Errors
+// encountered along the way are sent to the Errors() channel rather than returned, since there's
+// no caller left to return them to. It is a no-op if auto-creation is disabled.
+func (m *TopicManager) StartRefresher(ctx context.Context) {
+	if !m.config.Enable {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(defaultTopicRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.EnsureTopics(ctx); err != nil {
+					select {
+					case m.errCh <- err:
+					default:
+						klog.Errorf("topic refresh error dropped, Errors() channel full: %v", err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+func (m *TopicManager) specs() []TopicSpec {
+	numPartitions := m.config.NumPartitions
+	if numPartitions <= 0 {
+		numPartitions = defaultNumPartitions
+	}
+	replicationFactor := m.config.ReplicationFactor
+	if replicationFactor <= 0 {
+		replicationFactor = defaultReplicationFactor
+	}
+
+	specs := make([]TopicSpec, len(requiredTopics))
+	for i, topic := range requiredTopics {
+		specs[i] = TopicSpec{
+			Name:              topic,
+			NumPartitions:     numPartitions,
+			ReplicationFactor: replicationFactor,
+			ConfigEntries:     m.config.ConfigEntries,
+		}
+	}
+	return specs
+}
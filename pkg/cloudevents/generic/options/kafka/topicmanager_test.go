@@ -0,0 +1,90 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+)
+
+// fakeAdminClient records the topics it was asked to ensure.
+type fakeAdminClient struct {
+	ensureCalls [][]TopicSpec
+	ensureErr   error
+	closed      bool
+}
+
+func (c *fakeAdminClient) EnsureTopics(ctx context.Context, topics []TopicSpec) error {
+	c.ensureCalls = append(c.ensureCalls, topics)
+	return c.ensureErr
+}
+
+func (c *fakeAdminClient) Close() {
+	c.closed = true
+}
+
+func TestTopicManagerSpecs(t *testing.T) {
+	m := NewTopicManager(&fakeAdminClient{}, AutoCreateTopics{
+		Enable:            true,
+		NumPartitions:     3,
+		ReplicationFactor: 2,
+		ConfigEntries:     map[string]string{"retention.ms": "3600000"},
+	})
+
+	specs := m.specs()
+	if len(specs) != len(requiredTopics) {
+		t.Fatalf("expected %d specs, got %d", len(requiredTopics), len(specs))
+	}
+	for i, spec := range specs {
+		if spec.Name != requiredTopics[i] {
+			t.Errorf("spec %d: expected name %q, got %q", i, requiredTopics[i], spec.Name)
+		}
+		if spec.NumPartitions != 3 {
+			t.Errorf("spec %d: expected 3 partitions, got %d", i, spec.NumPartitions)
+		}
+		if spec.ReplicationFactor != 2 {
+			t.Errorf("spec %d: expected replication factor 2, got %d", i, spec.ReplicationFactor)
+		}
+		if spec.ConfigEntries["retention.ms"] != "3600000" {
+			t.Errorf("spec %d: expected retention.ms config entry to carry through", i)
+		}
+	}
+}
+
+func TestTopicManagerSpecs_Defaults(t *testing.T) {
+	m := NewTopicManager(&fakeAdminClient{}, AutoCreateTopics{Enable: true})
+
+	for _, spec := range m.specs() {
+		if spec.NumPartitions != defaultNumPartitions {
+			t.Errorf("expected default partition count %d, got %d", defaultNumPartitions, spec.NumPartitions)
+		}
+		if spec.ReplicationFactor != defaultReplicationFactor {
+			t.Errorf("expected default replication factor %d, got %d", defaultReplicationFactor, spec.ReplicationFactor)
+		}
+	}
+}
+
+func TestTopicManagerEnsureTopics_DisabledIsNoop(t *testing.T) {
+	admin := &fakeAdminClient{}
+	m := NewTopicManager(admin, AutoCreateTopics{Enable: false})
+
+	if err := m.EnsureTopics(context.Background()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(admin.ensureCalls) != 0 {
+		t.Errorf("expected EnsureTopics to be a no-op when auto-creation is disabled, got %d calls", len(admin.ensureCalls))
+	}
+}
+
+func TestTopicManagerEnsureTopics_Enabled(t *testing.T) {
+	admin := &fakeAdminClient{}
+	m := NewTopicManager(admin, AutoCreateTopics{Enable: true})
+
+	if err := m.EnsureTopics(context.Background()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(admin.ensureCalls) != 1 {
+		t.Fatalf("expected EnsureTopics to delegate to the admin client once, got %d calls", len(admin.ensureCalls))
+	}
+	if len(admin.ensureCalls[0]) != len(requiredTopics) {
+		t.Errorf("expected %d topics, got %d", len(requiredTopics), len(admin.ensureCalls[0]))
+	}
+}
@@ -0,0 +1,51 @@
+package work
+
+import (
+	"fmt"
+
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc"
+	kafkaoption "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/kafka"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/mqtt"
+)
+
+// ConfigLoader loads the protocol-specific options for configType from configFilePath.
+type ConfigLoader struct {
+	configType     string
+	configFilePath string
+}
+
+// NewConfigLoader returns a ConfigLoader for the given protocol ("mqtt", "grpc" or "kafka") and
+// config filepath.
+func NewConfigLoader(configType, configFilePath string) *ConfigLoader {
+	return &ConfigLoader{configType: configType, configFilePath: configFilePath}
+}
+
+// LoadConfig loads the protocol-specific options from l.configFilePath, returning the protocol
+// name alongside the options so callers that handle multiple protocols don't need to carry it
+// separately. For "kafka" this returns a *kafkaoption.LoaderOptions, a backend-neutral struct
+// (no confluent-kafka-go or franz-go types), since the caller hasn't chosen a Kafka client
+// backend at this layer — see kafkaoption.BuildLoaderOptionsFromFlags.
+func (l *ConfigLoader) LoadConfig() (string, any, error) {
+	switch l.configType {
+	case "mqtt":
+		options, err := mqtt.BuildMQTTOptionsFromFlags(l.configFilePath)
+		if err != nil {
+			return l.configType, nil, err
+		}
+		return l.configType, options, nil
+	case "grpc":
+		options, err := grpc.BuildGRPCOptionsFromFlags(l.configFilePath)
+		if err != nil {
+			return l.configType, nil, err
+		}
+		return l.configType, options, nil
+	case "kafka":
+		options, err := kafkaoption.BuildLoaderOptionsFromFlags(l.configFilePath)
+		if err != nil {
+			return l.configType, nil, err
+		}
+		return l.configType, options, nil
+	default:
+		return l.configType, nil, fmt.Errorf("unsupported config type %q", l.configType)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc"
 	kafkaoption "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/kafka"
@@ -24,8 +23,27 @@ topics:
 url: grpc
 `
 	kafkaConfig = `
-configs:
-  bootstrap.servers: test
+bootstrapServer: test
+`
+	kafkaSASLConfig = `
+bootstrapServer: test
+sasl:
+  enable: true
+  mechanism: PLAIN
+  username: alice
+  password: secret
+`
+	kafkaOAuthConfig = `
+bootstrapServer: test
+sasl:
+  enable: true
+  mechanism: OAUTHBEARER
+oauth:
+  tokenURL: https://idp.example.com/oauth/token
+  clientID: agent
+  clientSecret: secret
+  scopes:
+    - kafka
 `
 )
 
@@ -48,6 +66,18 @@ func TestLoadConfig(t *testing.T) {
 	}
 	defer os.Remove(kafkaConfigFile.Name())
 
+	kafkaSASLConfigFile, err := os.CreateTemp("", "kafka-sasl-config-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(kafkaSASLConfigFile.Name())
+
+	kafkaOAuthConfigFile, err := os.CreateTemp("", "kafka-oauth-config-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(kafkaOAuthConfigFile.Name())
+
 	if err := os.WriteFile(mqttConfigFile.Name(), []byte(mqttConfig), 0644); err != nil {
 		t.Fatal(err)
 	}
@@ -60,6 +90,14 @@ func TestLoadConfig(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	if err := os.WriteFile(kafkaSASLConfigFile.Name(), []byte(kafkaSASLConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(kafkaOAuthConfigFile.Name(), []byte(kafkaOAuthConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
 	cases := []struct {
 		name           string
 		configType     string
@@ -92,13 +130,48 @@ func TestLoadConfig(t *testing.T) {
 			name:           "kafka config",
 			configType:     "kafka",
 			configFilePath: kafkaConfigFile.Name(),
-			expectedConfig: &kafkaoption.KafkaOptions{
-				ConfigMap: &kafka.ConfigMap{
-					"bootstrap.servers": "test",
+			expectedConfig: &kafkaoption.LoaderOptions{
+				Config: &kafkaoption.KafkaConfig{
+					BootstrapServer: "test",
+				},
+			},
+		},
+		{
+			name:           "kafka config with sasl",
+			configType:     "kafka",
+			configFilePath: kafkaSASLConfigFile.Name(),
+			expectedConfig: &kafkaoption.LoaderOptions{
+				Config: &kafkaoption.KafkaConfig{
+					BootstrapServer: "test",
+					SASL: &kafkaoption.SASLConfig{
+						Enable:    true,
+						Mechanism: kafkaoption.SASLMechanismPlain,
+						Username:  "alice",
+						Password:  "secret",
+					},
 				},
-				Topics: &types.Topics{
-					SourceEvents: "spec",
-					AgentEvents:  "status",
+			},
+		},
+		{
+			// LoaderOptions carries the oauth section verbatim (unlike KafkaOptions.ConfigMap,
+			// which has no place for it), so work.ConfigLoader callers can hand it to
+			// kafka.StartOAuthTokenRefresher once a backend-specific client is built.
+			name:           "kafka config with oauth",
+			configType:     "kafka",
+			configFilePath: kafkaOAuthConfigFile.Name(),
+			expectedConfig: &kafkaoption.LoaderOptions{
+				Config: &kafkaoption.KafkaConfig{
+					BootstrapServer: "test",
+					SASL: &kafkaoption.SASLConfig{
+						Enable:    true,
+						Mechanism: kafkaoption.SASLMechanismOAuthBearer,
+					},
+					OAuth: &kafkaoption.OAuthConfig{
+						TokenURL:     "https://idp.example.com/oauth/token",
+						ClientID:     "agent",
+						ClientSecret: "secret",
+						Scopes:       []string{"kafka"},
+					},
 				},
 			},
 		},